@@ -0,0 +1,97 @@
+// Package auth issues and validates HS256 JWTs and provides the HTTP
+// middleware that enforces them.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ctxKey is an unexported type to avoid collisions with context keys set
+// by other packages.
+type ctxKey int
+
+const userIDKey ctxKey = iota
+
+// tokenTTL is how long an issued token remains valid.
+const tokenTTL = 24 * time.Hour
+
+// claims is the JWT payload used to identify the authenticated user.
+type claims struct {
+	UserID int `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+// IssueToken returns a signed HS256 JWT asserting userID, valid for
+// tokenTTL, signed with secret.
+func IssueToken(secret string, userID int) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+		},
+	})
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("auth: signing token: %w", err)
+	}
+	return signed, nil
+}
+
+// verifyToken parses and validates tokenStr, returning the user id it asserts.
+func verifyToken(secret, tokenStr string) (int, error) {
+	parsed, err := jwt.ParseWithClaims(tokenStr, &claims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("auth: unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("auth: parsing token: %w", err)
+	}
+	c, ok := parsed.Claims.(*claims)
+	if !ok || !parsed.Valid {
+		return 0, errors.New("auth: invalid token")
+	}
+	return c.UserID, nil
+}
+
+// RequireAuth returns middleware that validates the Authorization: Bearer
+// header against secret and injects the authenticated user id into the
+// request context, rejecting the request with 401 on any failure.
+func RequireAuth(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			const prefix = "Bearer "
+			if !strings.HasPrefix(header, prefix) {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			userID, err := verifyToken(secret, strings.TrimPrefix(header, prefix))
+			if err != nil {
+				http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserIDFromContext returns the authenticated user id injected by
+// RequireAuth, and false if the context carries none.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(userIDKey).(int)
+	return id, ok
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func TestIssueAndVerifyToken(t *testing.T) {
+	token, err := IssueToken(testSecret, 42)
+	if err != nil {
+		t.Fatalf("IssueToken returned error: %v", err)
+	}
+
+	userID, err := verifyToken(testSecret, token)
+	if err != nil {
+		t.Fatalf("verifyToken returned error: %v", err)
+	}
+	if userID != 42 {
+		t.Fatalf("expected user id 42, got %d", userID)
+	}
+}
+
+func TestVerifyTokenWrongSecret(t *testing.T) {
+	token, err := IssueToken(testSecret, 1)
+	if err != nil {
+		t.Fatalf("IssueToken returned error: %v", err)
+	}
+
+	if _, err := verifyToken("a-different-secret", token); err == nil {
+		t.Fatal("expected an error for a token signed with a different secret")
+	}
+}
+
+func TestVerifyTokenTamperedSignature(t *testing.T) {
+	token, err := IssueToken(testSecret, 1)
+	if err != nil {
+		t.Fatalf("IssueToken returned error: %v", err)
+	}
+
+	tampered := token[:len(token)-1] + "x"
+	if tampered == token {
+		t.Fatal("test setup failed to produce a different signature")
+	}
+
+	if _, err := verifyToken(testSecret, tampered); err == nil {
+		t.Fatal("expected an error for a tampered signature")
+	}
+}
+
+func TestVerifyTokenExpired(t *testing.T) {
+	now := time.Now()
+	expired := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		UserID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now.Add(-2 * tokenTTL)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(-1 * time.Hour)),
+		},
+	})
+	signed, err := expired.SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("signing expired token: %v", err)
+	}
+
+	if _, err := verifyToken(testSecret, signed); err == nil {
+		t.Fatal("expected an error for an expired token")
+	}
+}
+
+func TestVerifyTokenRejectsNoneAlg(t *testing.T) {
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, claims{UserID: 1})
+	token, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("signing none-alg token: %v", err)
+	}
+
+	if _, err := verifyToken(testSecret, token); err == nil {
+		t.Fatal("expected verifyToken to reject an alg=none token")
+	}
+}
+
+func TestVerifyTokenRejectsRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %v", err)
+	}
+	rsToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims{UserID: 1})
+	signed, err := rsToken.SignedString(key)
+	if err != nil {
+		t.Fatalf("signing RS256 token: %v", err)
+	}
+
+	if _, err := verifyToken(testSecret, signed); err == nil {
+		t.Fatal("expected verifyToken to reject an RS256 token")
+	}
+}
+
+func TestVerifyTokenMalformed(t *testing.T) {
+	if _, err := verifyToken(testSecret, "not-a-jwt"); err == nil {
+		t.Fatal("expected an error for a malformed token")
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	validToken, err := IssueToken(testSecret, 7)
+	if err != nil {
+		t.Fatalf("IssueToken returned error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+	}{
+		{name: "missing header", authHeader: "", wantStatus: http.StatusUnauthorized},
+		{name: "no bearer prefix", authHeader: validToken, wantStatus: http.StatusUnauthorized},
+		{name: "malformed token", authHeader: "Bearer not-a-jwt", wantStatus: http.StatusUnauthorized},
+		{name: "valid token", authHeader: "Bearer " + validToken, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUserID int
+			var gotOK bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, gotOK = UserIDFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+			RequireAuth(testSecret)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if !gotOK || gotUserID != 7 {
+					t.Fatalf("expected injected user id 7, got %d (ok=%v)", gotUserID, gotOK)
+				}
+			}
+		})
+	}
+}
+
+func TestUserIDFromContextMissing(t *testing.T) {
+	if _, ok := UserIDFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); ok {
+		t.Fatal("expected no user id in an empty context")
+	}
+}
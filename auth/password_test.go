@@ -0,0 +1,17 @@
+package auth
+
+import "testing"
+
+func TestHashAndCheckPassword(t *testing.T) {
+	hash, err := HashPassword("hunter2")
+	if err != nil {
+		t.Fatalf("HashPassword returned error: %v", err)
+	}
+
+	if !CheckPassword(hash, "hunter2") {
+		t.Fatal("expected the correct password to check out")
+	}
+	if CheckPassword(hash, "wrong-password") {
+		t.Fatal("expected an incorrect password to fail")
+	}
+}
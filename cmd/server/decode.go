@@ -0,0 +1,24 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// decodeJSON enforces a JSON content type and decodes r.Body into dst,
+// rejecting any field not present on dst so typos and stale clients fail
+// loudly instead of being silently ignored.
+func decodeJSON(r *http.Request, dst interface{}) error {
+	if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		return fmt.Errorf("expected Content-Type application/json, got %q", ct)
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("decoding request body: %w", err)
+	}
+	return nil
+}
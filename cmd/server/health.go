@@ -0,0 +1,25 @@
+package main
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// healthz reports process liveness: if the handler runs at all, the
+// process is alive.
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// readyz reports readiness by pinging the database pool, so a load
+// balancer can stop routing traffic to an instance that has lost its
+// connection.
+func (s *Server) readyz(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.Ping(r.Context()); err != nil {
+		s.logger.Error("readiness check failed", zap.Error(err))
+		writeError(w, http.StatusServiceUnavailable, "not_ready", "database is unreachable")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
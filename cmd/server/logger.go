@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newLogger builds a production zap logger whose level is driven by the
+// LOG_LEVEL config value, so operators can turn up verbosity (e.g.
+// LOG_LEVEL=debug) without a code change.
+func newLogger(level string) (*zap.Logger, error) {
+	var zapLevel zapcore.Level
+	if err := zapLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("parsing LOG_LEVEL %q: %w", level, err)
+	}
+
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(zapLevel)
+	return cfg.Build()
+}
@@ -0,0 +1,269 @@
+// server.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/wangdangel/Augmentorium/auth"
+	"github.com/wangdangel/Augmentorium/config"
+	"github.com/wangdangel/Augmentorium/store"
+)
+
+// shutdownTimeout bounds how long graceful shutdown waits for in-flight
+// requests to drain before forcing the server closed.
+const shutdownTimeout = 10 * time.Second
+
+// userStore is the subset of *store.UserStore the HTTP handlers rely on,
+// narrowed to an interface so tests can exercise the handlers against a
+// fake instead of a real database.
+type userStore interface {
+	List(ctx context.Context) ([]store.User, error)
+	Get(ctx context.Context, id int) (store.User, error)
+	GetByEmail(ctx context.Context, email string) (store.User, error)
+	Create(ctx context.Context, u store.User) (store.User, error)
+	Update(ctx context.Context, u store.User) error
+	Delete(ctx context.Context, id int) error
+	Ping(ctx context.Context) error
+}
+
+// Server holds the shared dependencies for the HTTP handlers.
+type Server struct {
+	store     userStore
+	logger    *zap.Logger
+	jwtSecret string
+}
+
+func main() {
+	// Load layered .env configuration and fail fast on misconfiguration
+	cfg := config.Load()
+
+	// Initialize logger at the level requested by LOG_LEVEL
+	logger, err := newLogger(cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	ctx := context.Background()
+	userStore, err := store.NewUserStore(ctx, cfg.DBDSN)
+	if err != nil {
+		logger.Fatal("failed to connect to database", zap.Error(err))
+	}
+	defer userStore.Close()
+
+	srv := &Server{store: userStore, logger: logger, jwtSecret: cfg.JWTSecret}
+
+	// Set up router
+	r := mux.NewRouter()
+	r.HandleFunc("/healthz", srv.healthz).Methods("GET")
+	r.HandleFunc("/readyz", srv.readyz).Methods("GET")
+	r.HandleFunc("/login", srv.login).Methods("POST")
+
+	protected := r.PathPrefix("").Subrouter()
+	protected.Use(auth.RequireAuth(cfg.JWTSecret))
+	protected.HandleFunc("/users", srv.getUsers).Methods("GET")
+	protected.HandleFunc("/users", srv.createUser).Methods("POST")
+	protected.HandleFunc("/users/{id:[0-9]+}", srv.getUser).Methods("GET")
+	protected.HandleFunc("/users/{id:[0-9]+}", srv.updateUser).Methods("PUT")
+	protected.HandleFunc("/users/{id:[0-9]+}", srv.deleteUser).Methods("DELETE")
+	protected.HandleFunc("/me", srv.getMe).Methods("GET")
+
+	accessLog := handlers.CombinedLoggingHandler(zapWriter{logger}, r)
+	handler := handlers.ProxyHeaders(accessLog)
+
+	httpSrv := &http.Server{
+		Addr:    cfg.ListenAddr,
+		Handler: handler,
+	}
+
+	go func() {
+		if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("server failed", zap.Error(err))
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop()
+
+	logger.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("graceful shutdown failed", zap.Error(err))
+	}
+}
+
+// loginRequest is the body expected by POST /login.
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	u, err := s.store.GetByEmail(r.Context(), req.Email)
+	if err != nil || !auth.CheckPassword(u.PasswordHash, req.Password) {
+		writeError(w, http.StatusUnauthorized, "invalid_credentials", "invalid email or password")
+		return
+	}
+
+	token, err := auth.IssueToken(s.jwtSecret, u.ID)
+	if err != nil {
+		s.logger.Error("failed to issue token", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+func (s *Server) getUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := s.store.List(r.Context())
+	if err != nil {
+		s.logger.Error("failed to list users", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+	json.NewEncoder(w).Encode(users)
+}
+
+// userRequest is the body expected by POST /users and PUT /users/{id}.
+type userRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password,omitempty"`
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var req userRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if req.Name == "" || req.Email == "" || req.Password == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "name, email and password are required")
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		s.logger.Error("failed to hash password", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+
+	u, err := s.store.Create(r.Context(), store.User{Name: req.Name, Email: req.Email, PasswordHash: hash})
+	if errors.Is(err, store.ErrConflict) {
+		writeError(w, http.StatusConflict, "email_taken", "a user with that email already exists")
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to create user", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(u)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	u, err := s.store.Get(r.Context(), id)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "user_not_found", "user not found")
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to get user", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+	json.NewEncoder(w).Encode(u)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	var req userRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if req.Name == "" || req.Email == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "name and email are required")
+		return
+	}
+
+	err := s.store.Update(r.Context(), store.User{ID: id, Name: req.Name, Email: req.Email})
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "user_not_found", "user not found")
+		return
+	}
+	if errors.Is(err, store.ErrConflict) {
+		writeError(w, http.StatusConflict, "email_taken", "a user with that email already exists")
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to update user", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	err := s.store.Delete(r.Context(), id)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "user_not_found", "user not found")
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to delete user", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+
+	u, err := s.store.Get(r.Context(), userID)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "user_not_found", "user not found")
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to get current user", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "internal_error", "internal server error")
+		return
+	}
+	json.NewEncoder(w).Encode(u)
+}
@@ -0,0 +1,435 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/wangdangel/Augmentorium/auth"
+	"github.com/wangdangel/Augmentorium/store"
+)
+
+// fakeStore is an in-memory userStore used to drive the handlers under
+// test without a real database.
+type fakeStore struct {
+	users      map[int]store.User
+	nextID     int
+	emailTaken bool // forces the next Create/Update to look like a unique violation
+}
+
+func newFakeStore(users ...store.User) *fakeStore {
+	f := &fakeStore{users: map[int]store.User{}}
+	for _, u := range users {
+		f.users[u.ID] = u
+		if u.ID >= f.nextID {
+			f.nextID = u.ID + 1
+		}
+	}
+	return f
+}
+
+func (f *fakeStore) List(ctx context.Context) ([]store.User, error) {
+	var out []store.User
+	for _, u := range f.users {
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) Get(ctx context.Context, id int) (store.User, error) {
+	u, ok := f.users[id]
+	if !ok {
+		return store.User{}, store.ErrNotFound
+	}
+	return u, nil
+}
+
+func (f *fakeStore) GetByEmail(ctx context.Context, email string) (store.User, error) {
+	for _, u := range f.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return store.User{}, store.ErrNotFound
+}
+
+func (f *fakeStore) Create(ctx context.Context, u store.User) (store.User, error) {
+	if f.emailTaken {
+		return store.User{}, store.ErrConflict
+	}
+	u.ID = f.nextID
+	f.nextID++
+	f.users[u.ID] = u
+	return u, nil
+}
+
+func (f *fakeStore) Update(ctx context.Context, u store.User) error {
+	if f.emailTaken {
+		return store.ErrConflict
+	}
+	if _, ok := f.users[u.ID]; !ok {
+		return store.ErrNotFound
+	}
+	f.users[u.ID] = u
+	return nil
+}
+
+func (f *fakeStore) Delete(ctx context.Context, id int) error {
+	if _, ok := f.users[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(f.users, id)
+	return nil
+}
+
+func (f *fakeStore) Ping(ctx context.Context) error { return nil }
+
+func newTestServer(fs *fakeStore) *Server {
+	return &Server{store: fs, logger: zap.NewNop(), jwtSecret: "test-secret"}
+}
+
+func newRouter(s *Server) *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/users", s.getUsers).Methods("GET")
+	r.HandleFunc("/users", s.createUser).Methods("POST")
+	r.HandleFunc("/users/{id:[0-9]+}", s.getUser).Methods("GET")
+	r.HandleFunc("/users/{id:[0-9]+}", s.updateUser).Methods("PUT")
+	r.HandleFunc("/users/{id:[0-9]+}", s.deleteUser).Methods("DELETE")
+	return r
+}
+
+// newAuthRouter wires /login and /me the same way main does, with /me
+// behind auth.RequireAuth, so tests can exercise the auth boundary
+// end-to-end.
+func newAuthRouter(s *Server) *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/login", s.login).Methods("POST")
+
+	protected := r.PathPrefix("").Subrouter()
+	protected.Use(auth.RequireAuth(s.jwtSecret))
+	protected.HandleFunc("/me", s.getMe).Methods("GET")
+	return r
+}
+
+func decodeError(t *testing.T, body *bytes.Buffer) errorResponse {
+	t.Helper()
+	var e errorResponse
+	if err := json.NewDecoder(body).Decode(&e); err != nil {
+		t.Fatalf("decoding error response: %v", err)
+	}
+	return e
+}
+
+func TestGetUsers(t *testing.T) {
+	s := newTestServer(newFakeStore(store.User{ID: 1, Name: "John Doe", Email: "john@example.com"}))
+	r := newRouter(s)
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var users []store.User
+	if err := json.NewDecoder(rec.Body).Decode(&users); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(users) != 1 || users[0].Email != "john@example.com" {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "found", path: "/users/1", wantStatus: http.StatusOK},
+		{name: "not found", path: "/users/99", wantStatus: http.StatusNotFound, wantCode: "user_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(newFakeStore(store.User{ID: 1, Name: "John Doe", Email: "john@example.com"}))
+			r := newRouter(s)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantCode != "" {
+				if got := decodeError(t, rec.Body).Code; got != tt.wantCode {
+					t.Fatalf("expected code %q, got %q", tt.wantCode, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		emailTaken  bool
+		wantStatus  int
+		wantCode    string
+	}{
+		{
+			name:        "created",
+			body:        `{"name":"Jane Doe","email":"jane@example.com","password":"hunter2"}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:        "missing content type",
+			body:        `{"name":"Jane Doe","email":"jane@example.com","password":"hunter2"}`,
+			contentType: "text/plain",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "invalid_request",
+		},
+		{
+			name:        "unknown field",
+			body:        `{"name":"Jane Doe","email":"jane@example.com","password":"hunter2","admin":true}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "invalid_request",
+		},
+		{
+			name:        "missing required field",
+			body:        `{"name":"Jane Doe"}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "invalid_request",
+		},
+		{
+			name:        "conflict",
+			body:        `{"name":"Jane Doe","email":"jane@example.com","password":"hunter2"}`,
+			contentType: "application/json",
+			emailTaken:  true,
+			wantStatus:  http.StatusConflict,
+			wantCode:    "email_taken",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFakeStore()
+			fs.emailTaken = tt.emailTaken
+			s := newTestServer(fs)
+			r := newRouter(s)
+
+			req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != "" {
+				if got := decodeError(t, rec.Body).Code; got != tt.wantCode {
+					t.Fatalf("expected code %q, got %q", tt.wantCode, got)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		contentType string
+		emailTaken  bool
+		wantStatus  int
+		wantCode    string
+	}{
+		{
+			name:        "updated",
+			path:        "/users/1",
+			body:        `{"name":"John Smith","email":"john@example.com"}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name:        "not found",
+			path:        "/users/99",
+			body:        `{"name":"John Smith","email":"john@example.com"}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusNotFound,
+			wantCode:    "user_not_found",
+		},
+		{
+			name:        "conflict",
+			path:        "/users/1",
+			body:        `{"name":"John Smith","email":"taken@example.com"}`,
+			contentType: "application/json",
+			emailTaken:  true,
+			wantStatus:  http.StatusConflict,
+			wantCode:    "email_taken",
+		},
+		{
+			name:        "invalid json",
+			path:        "/users/1",
+			body:        `{"name":`,
+			contentType: "application/json",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "invalid_request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFakeStore(store.User{ID: 1, Name: "John Doe", Email: "john@example.com"})
+			fs.emailTaken = tt.emailTaken
+			s := newTestServer(fs)
+			r := newRouter(s)
+
+			req := httptest.NewRequest(http.MethodPut, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != "" {
+				if got := decodeError(t, rec.Body).Code; got != tt.wantCode {
+					t.Fatalf("expected code %q, got %q", tt.wantCode, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "deleted", path: "/users/1", wantStatus: http.StatusNoContent},
+		{name: "not found", path: "/users/99", wantStatus: http.StatusNotFound, wantCode: "user_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(newFakeStore(store.User{ID: 1, Name: "John Doe", Email: "john@example.com"}))
+			r := newRouter(s)
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantCode != "" {
+				if got := decodeError(t, rec.Body).Code; got != tt.wantCode {
+					t.Fatalf("expected code %q, got %q", tt.wantCode, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	hash, err := auth.HashPassword("hunter2")
+	if err != nil {
+		t.Fatalf("HashPassword returned error: %v", err)
+	}
+	registered := store.User{ID: 1, Name: "John Doe", Email: "john@example.com", PasswordHash: hash}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "correct password", body: `{"email":"john@example.com","password":"hunter2"}`, wantStatus: http.StatusOK},
+		{name: "incorrect password", body: `{"email":"john@example.com","password":"wrong"}`, wantStatus: http.StatusUnauthorized},
+		{name: "unknown email", body: `{"email":"nobody@example.com","password":"hunter2"}`, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(newFakeStore(registered))
+			r := newAuthRouter(s)
+
+			req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				var body map[string]string
+				if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+					t.Fatalf("decoding login response: %v", err)
+				}
+				if body["token"] == "" {
+					t.Fatal("expected a non-empty token")
+				}
+			}
+		})
+	}
+}
+
+func TestGetMeRequiresAuth(t *testing.T) {
+	registered := store.User{ID: 1, Name: "John Doe", Email: "john@example.com"}
+
+	t.Run("no token", func(t *testing.T) {
+		s := newTestServer(newFakeStore(registered))
+		r := newAuthRouter(s)
+
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("valid token round-trips to the logged-in user", func(t *testing.T) {
+		s := newTestServer(newFakeStore(registered))
+		r := newAuthRouter(s)
+
+		token, err := auth.IssueToken(s.jwtSecret, registered.ID)
+		if err != nil {
+			t.Fatalf("IssueToken returned error: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got store.User
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("decoding /me response: %v", err)
+		}
+		if got.ID != registered.ID || got.Email != registered.Email {
+			t.Fatalf("expected user %+v, got %+v", registered, got)
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package main
+
+import "go.uber.org/zap"
+
+// zapWriter adapts a *zap.Logger to io.Writer so it can be used as the
+// destination for handlers.CombinedLoggingHandler, keeping access logs
+// flowing through the same sink as the rest of the application's
+// structured logs.
+type zapWriter struct {
+	logger *zap.Logger
+}
+
+func (w zapWriter) Write(p []byte) (int, error) {
+	w.logger.Info(string(p))
+	return len(p), nil
+}
@@ -0,0 +1,63 @@
+// Package config loads application configuration from layered .env files
+// and validates that required variables are present before the server
+// starts.
+package config
+
+import (
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// Config holds the runtime configuration for the server, populated from
+// environment variables after the .env files have been loaded.
+type Config struct {
+	ListenAddr string
+	DBDSN      string
+	JWTSecret  string
+	LogLevel   string
+}
+
+// Load reads ".env.default" (committed defaults) and then overlays
+// ".env" (local, git-ignored overrides) on top of it, validates that the
+// required variables are set, and returns the resulting Config.
+//
+// Missing files are not an error: .env.default may be absent in some
+// deployments, and .env is optional in production where real environment
+// variables are injected directly.
+func Load() *Config {
+	if err := godotenv.Load(".env.default"); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("config: failed to load .env.default: %v", err)
+	}
+	if err := godotenv.Overload(".env"); err != nil && !os.IsNotExist(err) {
+		log.Fatalf("config: failed to load .env: %v", err)
+	}
+
+	checkEnv([]string{"DB_DSN", "JWT_SECRET"})
+
+	return &Config{
+		ListenAddr: getEnvDefault("LISTEN_ADDR", ":8080"),
+		DBDSN:      os.Getenv("DB_DSN"),
+		JWTSecret:  os.Getenv("JWT_SECRET"),
+		LogLevel:   getEnvDefault("LOG_LEVEL", "info"),
+	}
+}
+
+// checkEnv fails fast with the offending variable name when a required
+// environment variable is missing or empty, rather than letting a
+// misconfiguration surface later as a runtime panic deep in a handler.
+func checkEnv(required []string) {
+	for _, name := range required {
+		if os.Getenv(name) == "" {
+			log.Fatalf("config: required environment variable %q is not set", name)
+		}
+	}
+}
+
+func getEnvDefault(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
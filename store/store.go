@@ -0,0 +1,174 @@
+// Package store provides a Postgres-backed persistence layer for the
+// application's domain types, built on pgxpool.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNotFound is returned when a lookup by id matches no row.
+var ErrNotFound = errors.New("store: not found")
+
+// ErrConflict is returned when a create or update would violate a unique
+// constraint, such as a duplicate email.
+var ErrConflict = errors.New("store: conflict")
+
+// uniqueViolation is the Postgres error code for a unique constraint
+// violation (23505).
+const uniqueViolation = "23505"
+
+// User is the persisted representation of an application user.
+type User struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"-"`
+}
+
+// dbpool is the subset of *pgxpool.Pool that UserStore relies on. It
+// exists so tests can substitute a pgxmock pool without a real database.
+type dbpool interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Ping(ctx context.Context) error
+	Close()
+}
+
+// UserStore is a repository over the users table.
+type UserStore struct {
+	pool dbpool
+}
+
+// NewUserStore opens a connection pool against dsn, pings it to fail
+// fast on a bad connection string, and returns a ready-to-use UserStore.
+func NewUserStore(ctx context.Context, dsn string) (*UserStore, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("store: creating pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("store: pinging database: %w", err)
+	}
+	return &UserStore{pool: pool}, nil
+}
+
+// Ping reports whether the underlying connection pool is reachable, for
+// use by readiness probes.
+func (s *UserStore) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+// Close releases all connections held by the pool.
+func (s *UserStore) Close() {
+	s.pool.Close()
+}
+
+// List returns every user ordered by id.
+func (s *UserStore) List(ctx context.Context) ([]User, error) {
+	rows, err := s.pool.Query(ctx, `SELECT id, name, email, password_hash FROM users ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("store: listing users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var u User
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.PasswordHash); err != nil {
+			return nil, fmt.Errorf("store: scanning user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: listing users: %w", err)
+	}
+	return users, nil
+}
+
+// Get returns the user with the given id, or ErrNotFound.
+func (s *UserStore) Get(ctx context.Context, id int) (User, error) {
+	var u User
+	err := s.pool.QueryRow(ctx, `SELECT id, name, email, password_hash FROM users WHERE id = $1`, id).
+		Scan(&u.ID, &u.Name, &u.Email, &u.PasswordHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("store: getting user %d: %w", id, err)
+	}
+	return u, nil
+}
+
+// GetByEmail returns the user with the given email, or ErrNotFound.
+func (s *UserStore) GetByEmail(ctx context.Context, email string) (User, error) {
+	var u User
+	err := s.pool.QueryRow(ctx, `SELECT id, name, email, password_hash FROM users WHERE email = $1`, email).
+		Scan(&u.ID, &u.Name, &u.Email, &u.PasswordHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("store: getting user by email %q: %w", email, err)
+	}
+	return u, nil
+}
+
+// Create inserts u and returns the row with its generated id.
+func (s *UserStore) Create(ctx context.Context, u User) (User, error) {
+	err := s.pool.QueryRow(ctx,
+		`INSERT INTO users (name, email, password_hash) VALUES ($1, $2, $3) RETURNING id`,
+		u.Name, u.Email, u.PasswordHash,
+	).Scan(&u.ID)
+	if isUniqueViolation(err) {
+		return User{}, ErrConflict
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("store: creating user: %w", err)
+	}
+	return u, nil
+}
+
+// Update overwrites the name and email of the user with u.ID, returning
+// ErrNotFound if no such user exists.
+func (s *UserStore) Update(ctx context.Context, u User) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE users SET name = $1, email = $2 WHERE id = $3`,
+		u.Name, u.Email, u.ID,
+	)
+	if isUniqueViolation(err) {
+		return ErrConflict
+	}
+	if err != nil {
+		return fmt.Errorf("store: updating user %d: %w", u.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// Delete removes the user with the given id, returning ErrNotFound if no
+// such user exists.
+func (s *UserStore) Delete(ctx context.Context, id int) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("store: deleting user %d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
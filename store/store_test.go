@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+)
+
+func newMockStore(t *testing.T) (*UserStore, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("creating mock pool: %v", err)
+	}
+	t.Cleanup(mock.Close)
+	return &UserStore{pool: mock}, mock
+}
+
+func TestUserStoreList(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	rows := pgxmock.NewRows([]string{"id", "name", "email", "password_hash"}).
+		AddRow(1, "John Doe", "john@example.com", "hash1").
+		AddRow(2, "Jane Doe", "jane@example.com", "hash2")
+	mock.ExpectQuery("SELECT id, name, email, password_hash FROM users ORDER BY id").
+		WillReturnRows(rows)
+
+	users, err := s.List(context.Background())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(users) != 2 || users[0].Name != "John Doe" || users[1].Email != "jane@example.com" {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserStoreGetNotFound(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectQuery("SELECT id, name, email, password_hash FROM users WHERE id = \\$1").
+		WithArgs(42).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "email", "password_hash"}))
+
+	_, err := s.Get(context.Background(), 42)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserStoreCreate(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectQuery("INSERT INTO users \\(name, email, password_hash\\) VALUES \\(\\$1, \\$2, \\$3\\) RETURNING id").
+		WithArgs("John Doe", "john@example.com", "hash").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(1))
+
+	u, err := s.Create(context.Background(), User{Name: "John Doe", Email: "john@example.com", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if u.ID != 1 {
+		t.Fatalf("expected id 1, got %d", u.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserStoreCreateConflict(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectQuery("INSERT INTO users \\(name, email, password_hash\\) VALUES \\(\\$1, \\$2, \\$3\\) RETURNING id").
+		WithArgs("John Doe", "john@example.com", "hash").
+		WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+
+	_, err := s.Create(context.Background(), User{Name: "John Doe", Email: "john@example.com", PasswordHash: "hash"})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserStoreUpdateNotFound(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectExec("UPDATE users SET name = \\$1, email = \\$2 WHERE id = \\$3").
+		WithArgs("John Doe", "john@example.com", 99).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := s.Update(context.Background(), User{ID: 99, Name: "John Doe", Email: "john@example.com"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserStoreDelete(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
+		WithArgs(1).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	if err := s.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}